@@ -0,0 +1,41 @@
+package avatarlib
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"image/color"
+)
+
+// DefaultPalette is a curated list of pleasant background colors, indexed by
+// a hash of an avatar's key. It mirrors the fixed palette approach used by
+// Vikunja's initials avatar provider.
+var DefaultPalette = []color.RGBA{
+	{R: 0xE6, G: 0x19, B: 0x4B, A: 0xFF}, // crimson
+	{R: 0x3C, G: 0xB4, B: 0x4B, A: 0xFF}, // green
+	{R: 0xFF, G: 0xE1, B: 0x19, A: 0xFF}, // yellow
+	{R: 0x43, G: 0x63, B: 0xD8, A: 0xFF}, // blue
+	{R: 0xF5, G: 0x82, B: 0x31, A: 0xFF}, // orange
+	{R: 0x91, G: 0x1E, B: 0xB4, A: 0xFF}, // purple
+	{R: 0x46, G: 0xF0, B: 0xF0, A: 0xFF}, // cyan
+	{R: 0xF0, G: 0x32, B: 0xE6, A: 0xFF}, // magenta
+	{R: 0xBC, G: 0xF6, B: 0x0C, A: 0xFF}, // lime
+	{R: 0x00, G: 0x80, B: 0x80, A: 0xFF}, // teal
+}
+
+// AvatarOptions configures the colors a Renderer (and RenderScaled) uses
+// when rendering an Avatar. The zero value selects the original grayscale rendering, so
+// existing callers are unaffected.
+type AvatarOptions struct {
+	Palette    []color.RGBA // Candidate background colors; empty means grayscale
+	Background color.RGBA   // Background color chosen for this avatar
+}
+
+// paletteBackground deterministically picks a color from palette by hashing
+// key, so the same key always yields the same background color. The index
+// is derived from the full first 4 bytes of the digest, not a single byte,
+// to avoid biasing low indices when len(palette) doesn't divide 256.
+func paletteBackground(key string, palette []color.RGBA) color.RGBA {
+	sum := sha256.Sum256([]byte(key))
+	idx := binary.BigEndian.Uint32(sum[:4]) % uint32(len(palette))
+	return palette[idx]
+}
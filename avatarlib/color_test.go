@@ -0,0 +1,45 @@
+package avatarlib
+
+import "testing"
+
+func TestPaletteBackgroundIsDeterministic(t *testing.T) {
+	a := paletteBackground("alice", DefaultPalette)
+	b := paletteBackground("alice", DefaultPalette)
+
+	if a != b {
+		t.Fatalf("expected the same key to always pick the same color, got %v and %v", a, b)
+	}
+}
+
+func TestPaletteBackgroundStaysWithinPalette(t *testing.T) {
+	keys := []string{"alice", "bob", "carol", "", "unicode-☃"}
+
+	for _, key := range keys {
+		c := paletteBackground(key, DefaultPalette)
+
+		found := false
+		for _, p := range DefaultPalette {
+			if p == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("paletteBackground(%q) = %v, not a member of DefaultPalette", key, c)
+		}
+	}
+}
+
+func TestGenerateKeyAvatarUsesDefaultPalette(t *testing.T) {
+	ka, err := GenerateKeyAvatar("dave", 8, 8, "none")
+	if err != nil {
+		t.Fatalf("GenerateKeyAvatar: %v", err)
+	}
+
+	if len(ka.Options.Palette) == 0 {
+		t.Fatal("expected GenerateKeyAvatar to set a palette")
+	}
+	if ka.Options.Background != paletteBackground("dave", DefaultPalette) {
+		t.Fatalf("expected background to match paletteBackground(\"dave\", ...), got %v", ka.Options.Background)
+	}
+}
@@ -0,0 +1,77 @@
+package avatarlib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// ImportOptions bounds what ImportAvatar will accept from an untrusted
+// upload, mirroring Gitea's picture settings (max dimensions and file size).
+type ImportOptions struct {
+	MaxWidth     int // Maximum accepted source image width, in pixels; 0 means unbounded
+	MaxHeight    int // Maximum accepted source image height, in pixels; 0 means unbounded
+	MaxFileSize  int // Maximum accepted upload size, in bytes; 0 means unbounded
+	TargetWidth  int // Bit-grid width of the resulting Avatar
+	TargetHeight int // Bit-grid height of the resulting Avatar
+}
+
+// ImportAvatar decodes an uploaded image (any format supported by
+// image.Decode: PNG, JPEG, GIF), downscales it to the target bit-grid size,
+// converts it to grayscale, and thresholds each pixel into the packed bit
+// representation used by Avatar. Pixels with luminance >= threshold become
+// set bits.
+func ImportAvatar(r io.Reader, threshold uint8, opts ImportOptions) (*Avatar, error) {
+	if opts.MaxFileSize > 0 {
+		r = io.LimitReader(r, int64(opts.MaxFileSize)+1)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading upload: %w", err)
+	}
+	if opts.MaxFileSize > 0 && len(data) > opts.MaxFileSize {
+		return nil, fmt.Errorf("upload exceeds maximum size of %d bytes", opts.MaxFileSize)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading upload header: %w", err)
+	}
+	if opts.MaxWidth > 0 && cfg.Width > opts.MaxWidth {
+		return nil, fmt.Errorf("image width %d exceeds maximum of %d", cfg.Width, opts.MaxWidth)
+	}
+	if opts.MaxHeight > 0 && cfg.Height > opts.MaxHeight {
+		return nil, fmt.Errorf("image height %d exceeds maximum of %d", cfg.Height, opts.MaxHeight)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding upload: %w", err)
+	}
+	bounds := src.Bounds()
+
+	width, height := opts.TargetWidth, opts.TargetHeight
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("invalid target dimensions")
+	}
+
+	scaled := image.NewGray(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Over, nil)
+
+	a := &Avatar{Width: width, Height: height, Pixels: make([]byte, (width*height+7)/8)}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a.SetPixel(x, y, scaled.GrayAt(x, y).Y >= threshold)
+		}
+	}
+
+	return a, nil
+}
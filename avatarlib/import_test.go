@@ -0,0 +1,91 @@
+package avatarlib
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodeTestPNG(t *testing.T, fill color.Gray, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, fill)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatalf("encoding test PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImportAvatarThresholdsWhiteToSetPixels(t *testing.T) {
+	data := encodeTestPNG(t, color.Gray{Y: 255}, 32, 32)
+
+	a, err := ImportAvatar(bytes.NewReader(data), 128, ImportOptions{TargetWidth: 8, TargetHeight: 8})
+	if err != nil {
+		t.Fatalf("ImportAvatar: %v", err)
+	}
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if !a.GetPixel(x, y) {
+				t.Fatalf("expected pixel (%d,%d) to be set for an all-white source", x, y)
+			}
+		}
+	}
+}
+
+func TestImportAvatarThresholdsBlackToUnsetPixels(t *testing.T) {
+	data := encodeTestPNG(t, color.Gray{Y: 0}, 32, 32)
+
+	a, err := ImportAvatar(bytes.NewReader(data), 128, ImportOptions{TargetWidth: 8, TargetHeight: 8})
+	if err != nil {
+		t.Fatalf("ImportAvatar: %v", err)
+	}
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.GetPixel(x, y) {
+				t.Fatalf("expected pixel (%d,%d) to be unset for an all-black source", x, y)
+			}
+		}
+	}
+}
+
+func TestImportAvatarRejectsOversizedDimensions(t *testing.T) {
+	data := encodeTestPNG(t, color.Gray{Y: 255}, 64, 64)
+
+	_, err := ImportAvatar(bytes.NewReader(data), 128, ImportOptions{
+		MaxWidth: 32, MaxHeight: 32, TargetWidth: 8, TargetHeight: 8,
+	})
+	if err == nil {
+		t.Fatal("expected an error for an image exceeding MaxWidth/MaxHeight")
+	}
+}
+
+func TestImportAvatarRejectsOversizedFile(t *testing.T) {
+	data := encodeTestPNG(t, color.Gray{Y: 255}, 32, 32)
+
+	_, err := ImportAvatar(bytes.NewReader(data), 128, ImportOptions{
+		MaxFileSize: len(data) - 1, TargetWidth: 8, TargetHeight: 8,
+	})
+	if err == nil {
+		t.Fatal("expected an error for an upload exceeding MaxFileSize")
+	}
+}
+
+func TestImportAvatarRejectsInvalidTargetDimensions(t *testing.T) {
+	data := encodeTestPNG(t, color.Gray{Y: 255}, 32, 32)
+
+	_, err := ImportAvatar(bytes.NewReader(data), 128, ImportOptions{})
+	if err == nil {
+		t.Fatal("expected an error for zero-value target dimensions")
+	}
+}
@@ -1,15 +1,10 @@
 package avatarlib
 
 import (
-	"bytes"
 	"crypto/rand"
 	"errors"
 	"fmt"
-	"image"
-	"image/color"
-	"image/png"
-	"os"
-	"path/filepath"
+	"io"
 )
 
 // AvatarGenerator defines methods for creating avatars, including symmetric variants.
@@ -27,6 +22,13 @@ type Avatar struct {
 // GenerateAvatar returns a new Avatar of given dimensions filled with random pixels.
 // Returns an error if dimensions are non-positive or random data cannot be read.
 func GenerateAvatar(width, height int) (*Avatar, error) {
+	return generateAvatar(rand.Reader, width, height)
+}
+
+// generateAvatar fills an Avatar of the given dimensions by reading bytes
+// from r, so callers can substitute a deterministic reader in place of
+// crypto/rand.Reader.
+func generateAvatar(r io.Reader, width, height int) (*Avatar, error) {
 	if width <= 0 || height <= 0 {
 		return nil, errors.New("invalid dimensions")
 	}
@@ -35,7 +37,7 @@ func GenerateAvatar(width, height int) (*Avatar, error) {
 	bytesLen := (bits + 7) / 8
 	pixels := make([]byte, bytesLen)
 
-	if _, err := rand.Read(pixels); err != nil {
+	if _, err := io.ReadFull(r, pixels); err != nil {
 		return nil, fmt.Errorf("random generation failed: %w", err)
 	}
 
@@ -74,6 +76,13 @@ func (a *Avatar) SetPixel(x, y int, val bool) {
 // and mirrored across the vertical center for symmetry.
 // Returns an error if dimensions are non-positive or random data cannot be read.
 func GenerateSymmetric(width, height int) (*Avatar, error) {
+	return generateSymmetric(rand.Reader, width, height)
+}
+
+// generateSymmetric builds a mirrored Avatar of the given dimensions by
+// reading bits from r, so callers can substitute a deterministic reader in
+// place of crypto/rand.Reader.
+func generateSymmetric(r io.Reader, width, height int) (*Avatar, error) {
 	if width <= 0 || height <= 0 {
 		return nil, errors.New("invalid dimensions")
 	}
@@ -81,10 +90,10 @@ func GenerateSymmetric(width, height int) (*Avatar, error) {
 	a := &Avatar{Width: width, Height: height, Pixels: make([]byte, (width*height+7)/8)}
 	halfWidth := (width + 1) / 2
 
+	b := make([]byte, 1)
 	for y := 0; y < height; y++ {
 		for x := 0; x < halfWidth; x++ {
-			b := make([]byte, 1)
-			if _, err := rand.Read(b); err != nil {
+			if _, err := io.ReadFull(r, b); err != nil {
 				return nil, fmt.Errorf("random read failed: %w", err)
 			}
 			val := (b[0] & 1) == 1
@@ -102,82 +111,62 @@ func GenerateSymmetric(width, height int) (*Avatar, error) {
 
 // KeyAvatar associates a unique string key with an Avatar instance.
 type KeyAvatar struct {
-	Key    string  // Unique identifier for the avatar
-	Avatar *Avatar // Underlying avatar data
+	Key     string        // Unique identifier for the avatar
+	Avatar  *Avatar       // Underlying avatar data
+	Options AvatarOptions // Rendering options, e.g. the palette-derived background
 }
 
 // GenerateKeyAvatar creates a random Avatar and wraps it with the provided key.
+// The background color is chosen deterministically from DefaultPalette by
+// hashing key, so the same key always renders with the same color.
 // Delegates to GenerateAvatar and returns an error on failure.
 func GenerateKeyAvatar(key string, width, height int, method string) (*KeyAvatar, error) {
-	var avatar *Avatar
-	var err error
+	avatar, err := generateKeyAvatar(rand.Reader, width, height, method)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyAvatar{
+		Key:    key,
+		Avatar: avatar,
+		Options: AvatarOptions{
+			Palette:    DefaultPalette,
+			Background: paletteBackground(key, DefaultPalette),
+		},
+	}, nil
+}
 
+// generateKeyAvatar dispatches to the requested generation method, reading
+// randomness from r.
+func generateKeyAvatar(r io.Reader, width, height int, method string) (*Avatar, error) {
 	switch method {
 	case "symmetric":
-		avatar, err = GenerateSymmetric(width, height)
+		return generateSymmetric(r, width, height)
 	case "none", "":
 		fallthrough
 	default:
-		avatar, err = GenerateAvatar(width, height)
-	}
-
-	if err != nil {
-		return nil, err
+		return generateAvatar(r, width, height)
 	}
-	return &KeyAvatar{Key: key, Avatar: avatar}, nil
 }
 
-// SaveAvatar renders the KeyAvatar as a PNG and writes it to folder/<key>.png.
-// Creates the folder if it does not exist.
-func SaveAvatar(folder string, ka *KeyAvatar) error {
-	pngData, err := RenderPNG(ka.Avatar)
+// SaveAvatar renders the KeyAvatar with r and saves it to storage under
+// <key><ext>, where ext is r's extension.
+func SaveAvatar(storage Storage, ka *KeyAvatar, r Renderer) error {
+	data, err := r.Render(ka.Avatar, ka.Options)
 	if err != nil {
 		return err
 	}
-
-	if err := os.MkdirAll(folder, 0755); err != nil {
-		return err
-	}
-
-	filename := filepath.Join(folder, ka.Key+".png")
-	return os.WriteFile(filename, pngData, 0644)
-}
-
-// GetAvatarPath constructs the filesystem path for the avatar PNG by key.
-func GetAvatarPath(folder, key string) string {
-	return filepath.Join(folder, key+".png")
-}
-
-// GetAvatarHTML returns an HTML <img> tag referencing the avatar under baseURL.
-func GetAvatarHTML(baseURL, key string, width, height int) string {
-	return fmt.Sprintf(`<img src="%s%s.png" width="%d" height="%d" alt="Avatar %s">`,
-		baseURL, key, width, height, key)
+	return storage.Save(ka.Key+r.Extension(), data)
 }
 
-// DeleteAvatar removes the avatar PNG file identified by key from the folder.
-func DeleteAvatar(folder, key string) error {
-	filename := filepath.Join(folder, key+".png")
-	return os.Remove(filename)
+// GetAvatarHTML returns an HTML <img> tag whose src is the storage URL for
+// the avatar rendered with r under key.
+func GetAvatarHTML(storage Storage, key string, width, height int, r Renderer) string {
+	return fmt.Sprintf(`<img src="%s" width="%d" height="%d" alt="Avatar %s">`,
+		storage.URL(key+r.Extension()), width, height, key)
 }
 
-// RenderPNG encodes the Avatar into a grayscale PNG image.
-// Set pixels (true) map to white and unset pixels (false) map to black.
-func RenderPNG(a *Avatar) ([]byte, error) {
-	img := image.NewGray(image.Rect(0, 0, a.Width, a.Height))
-
-	for y := 0; y < a.Height; y++ {
-		for x := 0; x < a.Width; x++ {
-			if a.GetPixel(x, y) {
-				img.SetGray(x, y, color.Gray{Y: 255})
-			} else {
-				img.SetGray(x, y, color.Gray{Y: 0})
-			}
-		}
-	}
-
-	buf := &bytes.Buffer{}
-	if err := png.Encode(buf, img); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+// DeleteAvatar removes the avatar rendered with r identified by key from
+// storage.
+func DeleteAvatar(storage Storage, key string, r Renderer) error {
+	return storage.Delete(key + r.Extension())
 }
@@ -0,0 +1,225 @@
+package avatarlib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// Renderer encodes an Avatar into a specific image format.
+type Renderer interface {
+	// Render encodes a using opts and returns the resulting file bytes.
+	Render(a *Avatar, opts AvatarOptions) ([]byte, error)
+	// RenderImage encodes an arbitrary already-built image, such as the
+	// enlarged tile RenderScaled produces, using the same format this
+	// Renderer's Render method would have used.
+	RenderImage(img image.Image) ([]byte, error)
+	// ContentType returns the MIME type of the rendered output.
+	ContentType() string
+	// Extension returns the filename extension (including the leading dot)
+	// of the rendered output.
+	Extension() string
+}
+
+// renderGray draws a into a grayscale image: true pixels are white, false
+// pixels are black.
+func renderGray(a *Avatar) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, a.Width, a.Height))
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.GetPixel(x, y) {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	return img
+}
+
+// renderColor draws a into an NRGBA image: true pixels are white, false
+// pixels are background.
+func renderColor(a *Avatar, background color.RGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, a.Width, a.Height))
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.GetPixel(x, y) {
+				img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+			} else {
+				img.SetNRGBA(x, y, color.NRGBA{R: background.R, G: background.G, B: background.B, A: background.A})
+			}
+		}
+	}
+
+	return img
+}
+
+// renderImage picks grayscale or palette-colored rendering depending on
+// whether opts.Palette is set, shared by every raster Renderer below.
+func renderImage(a *Avatar, opts AvatarOptions) image.Image {
+	if len(opts.Palette) == 0 {
+		return renderGray(a)
+	}
+	return renderColor(a, opts.Background)
+}
+
+// PNGRenderer renders an Avatar as a PNG image. With the zero value of
+// AvatarOptions passed to Render, it renders grayscale; when opts.Palette
+// is non-empty, it renders an NRGBA image using opts.Background instead.
+type PNGRenderer struct{}
+
+func (p PNGRenderer) Render(a *Avatar, opts AvatarOptions) ([]byte, error) {
+	return p.RenderImage(renderImage(a, opts))
+}
+
+func (PNGRenderer) RenderImage(img image.Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (PNGRenderer) ContentType() string { return "image/png" }
+func (PNGRenderer) Extension() string   { return ".png" }
+
+// JPEGRenderer renders an Avatar as a JPEG image at the configured quality
+// (1-100; the zero value falls back to jpeg.DefaultQuality).
+type JPEGRenderer struct {
+	Quality int
+}
+
+func (r JPEGRenderer) Render(a *Avatar, opts AvatarOptions) ([]byte, error) {
+	return r.RenderImage(renderImage(a, opts))
+}
+
+func (r JPEGRenderer) RenderImage(img image.Image) ([]byte, error) {
+	quality := r.Quality
+	if quality <= 0 {
+		quality = jpeg.DefaultQuality
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (JPEGRenderer) ContentType() string { return "image/jpeg" }
+func (JPEGRenderer) Extension() string   { return ".jpg" }
+
+// GIFRenderer renders an Avatar as a GIF image.
+type GIFRenderer struct{}
+
+func (g GIFRenderer) Render(a *Avatar, opts AvatarOptions) ([]byte, error) {
+	return g.RenderImage(renderImage(a, opts))
+}
+
+func (GIFRenderer) RenderImage(img image.Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gif.Encode(buf, toExactPalette(img), nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GIFRenderer) ContentType() string { return "image/gif" }
+func (GIFRenderer) Extension() string   { return ".gif" }
+
+// maxGIFColors is the largest palette an *image.Paletted can address: its
+// Pix slice stores one byte per pixel.
+const maxGIFColors = 256
+
+// toExactPalette converts img to an *image.Paletted. When img uses at most
+// maxGIFColors distinct colors (the common case: a two- or three-color
+// avatar tile), it builds an exact palette from those colors so gif.Encode
+// never substitutes the nearest websafe color for ones we explicitly chose
+// (e.g. a palette background). Otherwise it falls back to dithering against
+// a fixed 256-color palette, since an exact palette wouldn't fit in the
+// 8-bit-per-pixel format GIF requires.
+func toExactPalette(img image.Image) *image.Paletted {
+	bounds := img.Bounds()
+
+	if exact, ok := distinctColors(img, maxGIFColors); ok {
+		dst := image.NewPaletted(bounds, exact)
+		draw.Draw(dst, bounds, img, bounds.Min, draw.Src)
+		return dst
+	}
+
+	dst := image.NewPaletted(bounds, palette.Plan9)
+	draw.FloydSteinberg.Draw(dst, bounds, img, bounds.Min)
+	return dst
+}
+
+// distinctColors collects img's distinct colors, in first-seen order. It
+// returns ok=false without completing the scan if more than max distinct
+// colors are found.
+func distinctColors(img image.Image, max int) (color.Palette, bool) {
+	bounds := img.Bounds()
+
+	var colors color.Palette
+	seen := make(map[color.RGBA]bool)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			c := color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			if len(colors) >= max {
+				return nil, false
+			}
+			colors = append(colors, c)
+		}
+	}
+
+	return colors, true
+}
+
+// SVGRenderer renders an Avatar as a scalable SVG, emitting one <rect> per
+// set pixel. Unlike the raster renderers, this keeps edges lossless at any
+// display size.
+type SVGRenderer struct{}
+
+func (SVGRenderer) Render(a *Avatar, opts AvatarOptions) ([]byte, error) {
+	foreground := "#fff"
+	background := "#000"
+	if len(opts.Palette) > 0 {
+		background = fmt.Sprintf("#%02x%02x%02x", opts.Background.R, opts.Background.G, opts.Background.B)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		a.Width, a.Height)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="%s"/>`, a.Width, a.Height, background)
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.GetPixel(x, y) {
+				fmt.Fprintf(buf, `<rect x="%d" y="%d" width="1" height="1" fill="%s"/>`, x, y, foreground)
+			}
+		}
+	}
+
+	buf.WriteString(`</svg>`)
+	return buf.Bytes(), nil
+}
+
+func (SVGRenderer) RenderImage(img image.Image) ([]byte, error) {
+	return nil, errors.New("SVGRenderer does not support RenderImage: SVG is already resolution-independent")
+}
+
+func (SVGRenderer) ContentType() string { return "image/svg+xml" }
+func (SVGRenderer) Extension() string   { return ".svg" }
@@ -0,0 +1,203 @@
+package avatarlib
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// checkerAvatar returns a small Avatar with a checkerboard bit pattern, so
+// renderer tests have both set and unset pixels to verify.
+func checkerAvatar(width, height int) *Avatar {
+	a := &Avatar{Width: width, Height: height, Pixels: make([]byte, (width*height+7)/8)}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a.SetPixel(x, y, (x+y)%2 == 0)
+		}
+	}
+	return a
+}
+
+func TestPNGRendererRoundTrips(t *testing.T) {
+	a := checkerAvatar(4, 4)
+	opts := AvatarOptions{Palette: DefaultPalette, Background: DefaultPalette[0]}
+
+	data, err := PNGRenderer{}.Render(a, opts)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	decoded, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding PNG output: %v", err)
+	}
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			r, g, b, alpha := decoded.At(x, y).RGBA()
+			got := color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(alpha >> 8)}
+			want := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			if !a.GetPixel(x, y) {
+				want = opts.Background
+			}
+			if got != want {
+				t.Fatalf("pixel (%d,%d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	if (PNGRenderer{}).ContentType() != "image/png" {
+		t.Errorf("ContentType() = %q, want image/png", (PNGRenderer{}).ContentType())
+	}
+	if (PNGRenderer{}).Extension() != ".png" {
+		t.Errorf("Extension() = %q, want .png", (PNGRenderer{}).Extension())
+	}
+}
+
+func TestJPEGRendererProducesDecodableOutput(t *testing.T) {
+	a := checkerAvatar(8, 8)
+
+	r := JPEGRenderer{Quality: 90}
+	data, err := r.Render(a, AvatarOptions{})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding JPEG output: %v", err)
+	}
+	bounds := decoded.Bounds()
+	if bounds.Dx() != a.Width || bounds.Dy() != a.Height {
+		t.Fatalf("got %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), a.Width, a.Height)
+	}
+
+	if r.ContentType() != "image/jpeg" {
+		t.Errorf("ContentType() = %q, want image/jpeg", r.ContentType())
+	}
+	if r.Extension() != ".jpg" {
+		t.Errorf("Extension() = %q, want .jpg", r.Extension())
+	}
+}
+
+func TestJPEGRendererDefaultsQualityWhenUnset(t *testing.T) {
+	a := checkerAvatar(4, 4)
+
+	if _, err := (JPEGRenderer{}).Render(a, AvatarOptions{}); err != nil {
+		t.Fatalf("Render with zero-value Quality: %v", err)
+	}
+}
+
+func TestSVGRendererEmitsOneRectPerSetPixel(t *testing.T) {
+	a := checkerAvatar(4, 4)
+
+	data, err := SVGRenderer{}.Render(a, AvatarOptions{})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	svg := string(data)
+	wantSet := 0
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			if a.GetPixel(x, y) {
+				wantSet++
+			}
+		}
+	}
+
+	// One background <rect> plus one per set pixel.
+	gotRects := strings.Count(svg, "<rect")
+	if gotRects != wantSet+1 {
+		t.Fatalf("got %d <rect> elements, want %d (1 background + %d set pixels)", gotRects, wantSet+1, wantSet)
+	}
+
+	if !strings.Contains(svg, `viewBox="0 0 4 4"`) {
+		t.Fatalf("expected viewBox sized to the avatar, got %s", svg)
+	}
+
+	if (SVGRenderer{}).ContentType() != "image/svg+xml" {
+		t.Errorf("ContentType() = %q, want image/svg+xml", (SVGRenderer{}).ContentType())
+	}
+	if (SVGRenderer{}).Extension() != ".svg" {
+		t.Errorf("Extension() = %q, want .svg", (SVGRenderer{}).Extension())
+	}
+}
+
+func TestSVGRendererRenderImageIsUnsupported(t *testing.T) {
+	if _, err := (SVGRenderer{}).RenderImage(image.NewNRGBA(image.Rect(0, 0, 1, 1))); err == nil {
+		t.Fatal("expected RenderImage to be unsupported for SVGRenderer")
+	}
+}
+
+// manyColorImage returns an NRGBA image with n distinct colors, one per
+// pixel up to n, then repeating.
+func manyColorImage(n, width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	i := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: uint8(i), G: uint8(i / 2), B: uint8(i / 3), A: 255}
+			img.SetNRGBA(x, y, c)
+			i = (i + 1) % n
+		}
+	}
+	return img
+}
+
+func TestGIFRendererHandlesMoreThanMaxColors(t *testing.T) {
+	img := manyColorImage(1024, 32, 32)
+
+	data, err := GIFRenderer{}.RenderImage(img)
+	if err != nil {
+		t.Fatalf("RenderImage: %v", err)
+	}
+
+	decoded, err := gif.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding GIF output: %v", err)
+	}
+
+	bounds := decoded.Bounds()
+	if bounds.Dx() != 32 || bounds.Dy() != 32 {
+		t.Fatalf("got %dx%d, want 32x32", bounds.Dx(), bounds.Dy())
+	}
+
+	paletted, ok := decoded.(*image.Paletted)
+	if !ok {
+		t.Fatalf("expected a paletted image, got %T", decoded)
+	}
+	if len(paletted.Palette) > maxGIFColors {
+		t.Fatalf("decoded palette has %d colors, want <= %d", len(paletted.Palette), maxGIFColors)
+	}
+}
+
+func TestGIFRendererPreservesExactColorsUnderLimit(t *testing.T) {
+	img := manyColorImage(3, 8, 8)
+
+	data, err := GIFRenderer{}.RenderImage(img)
+	if err != nil {
+		t.Fatalf("RenderImage: %v", err)
+	}
+
+	decoded, err := gif.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding GIF output: %v", err)
+	}
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			wantR, wantG, wantB, wantA := img.At(x, y).RGBA()
+			gotR, gotG, gotB, gotA := decoded.At(x, y).RGBA()
+			if wantR != gotR || wantG != gotG || wantB != gotB || wantA != gotA {
+				t.Fatalf("pixel (%d,%d): got %v, want %v", x, y, decoded.At(x, y), img.At(x, y))
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package avatarlib
+
+import "testing"
+
+func TestInRoundedCornerCenterIsNeverExcluded(t *testing.T) {
+	const scale, radius = 10, 3
+	cx, cy := scale/2, scale/2
+
+	if inRoundedCorner(cx, cy, scale, radius) {
+		t.Fatalf("expected the cell center (%d,%d) to be inside the rounded shape", cx, cy)
+	}
+}
+
+func TestInRoundedCornerExtremeCornerIsExcluded(t *testing.T) {
+	const scale, radius = 10, 3
+
+	if !inRoundedCorner(0, 0, scale, radius) {
+		t.Fatal("expected the top-left pixel to be outside the rounded shape")
+	}
+	if !inRoundedCorner(scale-1, scale-1, scale, radius) {
+		t.Fatal("expected the bottom-right pixel to be outside the rounded shape")
+	}
+}
+
+func TestInRoundedCornerZeroRadiusExcludesNothing(t *testing.T) {
+	const scale = 10
+
+	for dy := 0; dy < scale; dy++ {
+		for dx := 0; dx < scale; dx++ {
+			if inRoundedCorner(dx, dy, scale, 0) {
+				t.Fatalf("expected radius 0 to exclude nothing, but (%d,%d) was excluded", dx, dy)
+			}
+		}
+	}
+}
+
+func TestBuildScaledImageDimensions(t *testing.T) {
+	a := &Avatar{Width: 4, Height: 4, Pixels: make([]byte, 2)}
+
+	tests := []struct {
+		name         string
+		scale        int
+		opts         ScaleOptions
+		wantW, wantH int
+	}{
+		{"no extras", 10, ScaleOptions{}, 40, 40},
+		{"with gutter", 10, ScaleOptions{Gutter: 2}, 46, 46},
+		{"with border", 10, ScaleOptions{Border: 5}, 50, 50},
+		{"gutter and border", 10, ScaleOptions{Gutter: 2, Border: 5}, 56, 56},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := buildScaledImage(a, tt.scale, AvatarOptions{}, tt.opts)
+			bounds := img.Bounds()
+			if bounds.Dx() != tt.wantW || bounds.Dy() != tt.wantH {
+				t.Errorf("got %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestRenderScaledRejectsNonPositiveScale(t *testing.T) {
+	a := &Avatar{Width: 2, Height: 2, Pixels: make([]byte, 1)}
+
+	if _, err := RenderScaled(a, 0, PNGRenderer{}, AvatarOptions{}, ScaleOptions{}); err == nil {
+		t.Fatal("expected an error for scale 0")
+	}
+}
+
+func TestRenderScaledRejectsSVGRenderer(t *testing.T) {
+	a := &Avatar{Width: 2, Height: 2, Pixels: make([]byte, 1)}
+
+	if _, err := RenderScaled(a, 10, SVGRenderer{}, AvatarOptions{}, ScaleOptions{}); err == nil {
+		t.Fatal("expected an error when scaling through SVGRenderer")
+	}
+}
@@ -0,0 +1,56 @@
+package avatarlib
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// seedReader returns a deterministic io.Reader that expands seed into a
+// byte stream via HKDF-SHA256, so it can stand in for crypto/rand.Reader
+// wherever reproducible output is required. HKDF caps the stream at
+// 255*32 = 8160 bytes; reads past that return an error, so generation
+// that needs more bytes than that fails.
+func seedReader(seed []byte) io.Reader {
+	return hkdf.New(sha256.New, seed, nil, []byte("avatarlib-seed"))
+}
+
+// keySeed derives a stable seed from key by hashing it with SHA-256, so the
+// same key always expands to the same avatar.
+func keySeed(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// GenerateAvatarFromSeed returns a new Avatar of the given dimensions whose
+// pixels are deterministically derived from seed: the same seed and
+// dimensions always produce the same Avatar.
+func GenerateAvatarFromSeed(seed []byte, width, height int) (*Avatar, error) {
+	return generateAvatar(seedReader(seed), width, height)
+}
+
+// GenerateSymmetricFromSeed returns a new mirrored Avatar of the given
+// dimensions whose pixels are deterministically derived from seed.
+func GenerateSymmetricFromSeed(seed []byte, width, height int) (*Avatar, error) {
+	return generateSymmetric(seedReader(seed), width, height)
+}
+
+// GenerateKeyAvatarSeeded creates an Avatar deterministically derived from
+// key (via SHA-256) and wraps it with that key, so regenerating the avatar
+// for the same key always reproduces the same pixels without persisting the
+// rendered PNG.
+func GenerateKeyAvatarSeeded(key string, width, height int, method string) (*KeyAvatar, error) {
+	avatar, err := generateKeyAvatar(seedReader(keySeed(key)), width, height, method)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyAvatar{
+		Key:    key,
+		Avatar: avatar,
+		Options: AvatarOptions{
+			Palette:    DefaultPalette,
+			Background: paletteBackground(key, DefaultPalette),
+		},
+	}, nil
+}
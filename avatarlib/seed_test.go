@@ -0,0 +1,69 @@
+package avatarlib
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateAvatarFromSeedIsDeterministic(t *testing.T) {
+	seed := []byte("same-seed")
+
+	a, err := GenerateAvatarFromSeed(seed, 8, 8)
+	if err != nil {
+		t.Fatalf("GenerateAvatarFromSeed: %v", err)
+	}
+	b, err := GenerateAvatarFromSeed(seed, 8, 8)
+	if err != nil {
+		t.Fatalf("GenerateAvatarFromSeed: %v", err)
+	}
+
+	if !bytes.Equal(a.Pixels, b.Pixels) {
+		t.Fatalf("expected identical pixels for the same seed, got %v and %v", a.Pixels, b.Pixels)
+	}
+}
+
+func TestGenerateAvatarFromSeedDiffersAcrossSeeds(t *testing.T) {
+	a, err := GenerateAvatarFromSeed([]byte("seed-one"), 8, 8)
+	if err != nil {
+		t.Fatalf("GenerateAvatarFromSeed: %v", err)
+	}
+	b, err := GenerateAvatarFromSeed([]byte("seed-two"), 8, 8)
+	if err != nil {
+		t.Fatalf("GenerateAvatarFromSeed: %v", err)
+	}
+
+	if bytes.Equal(a.Pixels, b.Pixels) {
+		t.Fatalf("expected different pixels for different seeds")
+	}
+}
+
+func TestGenerateSymmetricFromSeedIsMirrored(t *testing.T) {
+	a, err := GenerateSymmetricFromSeed([]byte("mirror-seed"), 8, 8)
+	if err != nil {
+		t.Fatalf("GenerateSymmetricFromSeed: %v", err)
+	}
+
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			mirrorX := a.Width - 1 - x
+			if a.GetPixel(x, y) != a.GetPixel(mirrorX, y) {
+				t.Fatalf("pixel (%d,%d) not mirrored at (%d,%d)", x, y, mirrorX, y)
+			}
+		}
+	}
+}
+
+func TestGenerateKeyAvatarSeededIsDeterministic(t *testing.T) {
+	a, err := GenerateKeyAvatarSeeded("alice", 8, 8, "none")
+	if err != nil {
+		t.Fatalf("GenerateKeyAvatarSeeded: %v", err)
+	}
+	b, err := GenerateKeyAvatarSeeded("alice", 8, 8, "none")
+	if err != nil {
+		t.Fatalf("GenerateKeyAvatarSeeded: %v", err)
+	}
+
+	if !bytes.Equal(a.Avatar.Pixels, b.Avatar.Pixels) {
+		t.Fatalf("expected identical pixels for the same key")
+	}
+}
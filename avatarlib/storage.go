@@ -0,0 +1,189 @@
+package avatarlib
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Storage abstracts the underlying location where rendered avatar files are
+// kept, so callers can switch between local disk and object storage without
+// touching the rest of avatarlib.
+type Storage interface {
+	// Save writes data under key, creating or overwriting any existing object.
+	Save(key string, data []byte) error
+	// Delete removes the object stored under key.
+	Delete(key string) error
+	// URL returns a URL suitable for embedding in an <img> tag for key.
+	URL(key string) string
+	// Open returns a reader for the object stored under key. Callers must
+	// close the returned ReadCloser.
+	Open(key string) (io.ReadCloser, error)
+}
+
+// FilesystemStorage implements Storage on top of a local folder, preserving
+// the original on-disk layout of <folder>/<key>.
+type FilesystemStorage struct {
+	Folder  string // Base directory avatars are written into
+	BaseURL string // URL prefix prepended to keys by URL
+}
+
+// NewFilesystemStorage returns a FilesystemStorage rooted at folder, serving
+// URLs under baseURL.
+func NewFilesystemStorage(folder, baseURL string) *FilesystemStorage {
+	return &FilesystemStorage{Folder: folder, BaseURL: baseURL}
+}
+
+// Save writes data to <folder>/<key>, creating the folder if necessary.
+func (f *FilesystemStorage) Save(key string, data []byte) error {
+	if err := os.MkdirAll(f.Folder, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(f.Folder, key), data, 0644)
+}
+
+// Delete removes <folder>/<key>.
+func (f *FilesystemStorage) Delete(key string) error {
+	return os.Remove(filepath.Join(f.Folder, key))
+}
+
+// URL returns BaseURL+key.
+func (f *FilesystemStorage) URL(key string) string {
+	return f.BaseURL + key
+}
+
+// Open opens <folder>/<key> for reading.
+func (f *FilesystemStorage) Open(key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(f.Folder, key))
+}
+
+// Path constructs the on-disk path of the avatar identified by key as
+// rendered by r, e.g. <folder>/<key>.svg. This is only meaningful for
+// FilesystemStorage; other Storage implementations have no local path.
+func (f *FilesystemStorage) Path(key string, r Renderer) string {
+	return filepath.Join(f.Folder, key+r.Extension())
+}
+
+// S3Storage implements Storage on top of an S3-compatible object store
+// (AWS S3, MinIO, etc.) via the MinIO Go SDK.
+type S3Storage struct {
+	client  *minio.Client
+	bucket  string
+	prefix  string // Optional key prefix, e.g. "avatars/"
+	baseURL string // URL prefix prepended to keys by URL
+}
+
+// S3Config holds the parameters needed to connect to an S3-compatible
+// endpoint and address objects within a bucket.
+type S3Config struct {
+	Endpoint        string // Host[:port] of the S3-compatible service
+	AccessKeyID     string
+	SecretAccessKey string
+	Bucket          string
+	UseTLS          bool   // Whether to connect over HTTPS
+	Prefix          string // Optional key prefix within the bucket
+	BaseURL         string // URL prefix used to build public URLs for objects
+}
+
+// NewS3Storage creates an S3Storage from cfg, verifying that a client can be
+// constructed for the given endpoint and credentials.
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseTLS,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating minio client: %w", err)
+	}
+
+	return &S3Storage{
+		client:  client,
+		bucket:  cfg.Bucket,
+		prefix:  cfg.Prefix,
+		baseURL: cfg.BaseURL,
+	}, nil
+}
+
+func (s *S3Storage) objectName(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return path.Join(s.prefix, key)
+}
+
+// Save uploads data to the configured bucket under key.
+func (s *S3Storage) Save(key string, data []byte) error {
+	ctx := context.Background()
+	_, err := s.client.PutObject(ctx, s.bucket, s.objectName(key), bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("uploading %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the object stored under key.
+func (s *S3Storage) Delete(key string) error {
+	ctx := context.Background()
+	if err := s.client.RemoveObject(ctx, s.bucket, s.objectName(key), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("removing %s: %w", key, err)
+	}
+	return nil
+}
+
+// URL returns BaseURL+key if BaseURL is set, otherwise it falls back to a
+// direct (unsigned) object URL on the configured endpoint.
+func (s *S3Storage) URL(key string) string {
+	if s.baseURL != "" {
+		return s.baseURL + key
+	}
+	scheme := "http"
+	if s.client.EndpointURL().Scheme != "" {
+		scheme = s.client.EndpointURL().Scheme
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, s.client.EndpointURL().Host, s.bucket, s.objectName(key))
+}
+
+// Open streams the object stored under key.
+func (s *S3Storage) Open(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	obj, err := s.client.GetObject(ctx, s.bucket, s.objectName(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", key, err)
+	}
+	return obj, nil
+}
+
+// Migrate copies every key in keys from src to dst, reading each object
+// fully into memory before writing it. It mirrors Gitea's migrate-storage
+// command, which re-homes existing files when switching backends.
+func Migrate(src, dst Storage, keys []string) error {
+	for _, key := range keys {
+		r, err := src.Open(key)
+		if err != nil {
+			return fmt.Errorf("opening %s from source: %w", key, err)
+		}
+
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s from source: %w", key, err)
+		}
+
+		if err := dst.Save(key, data); err != nil {
+			return fmt.Errorf("saving %s to destination: %w", key, err)
+		}
+	}
+	return nil
+}
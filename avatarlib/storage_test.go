@@ -0,0 +1,103 @@
+package avatarlib
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilesystemStorageRoundTrip(t *testing.T) {
+	s := NewFilesystemStorage(t.TempDir(), "https://example.com/avatars/")
+
+	want := []byte("hello avatar")
+	if err := s.Save("a.png", want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	r, err := s.Open("a.png")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("reading opened object: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got, want := s.URL("a.png"), "https://example.com/avatars/a.png"; got != want {
+		t.Fatalf("URL() = %q, want %q", got, want)
+	}
+
+	if err := s.Delete("a.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Open("a.png"); !os.IsNotExist(err) {
+		t.Fatalf("Open after Delete: got err %v, want a not-exist error", err)
+	}
+}
+
+func TestFilesystemStorageSaveCreatesFolder(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "nested", "avatars")
+	s := NewFilesystemStorage(folder, "")
+
+	if err := s.Save("a.png", []byte("data")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(folder, "a.png")); err != nil {
+		t.Fatalf("expected file to exist: %v", err)
+	}
+}
+
+func TestFilesystemStoragePath(t *testing.T) {
+	s := NewFilesystemStorage("/data/avatars", "")
+
+	got := s.Path("user-123", PNGRenderer{})
+	want := filepath.Join("/data/avatars", "user-123.png")
+	if got != want {
+		t.Fatalf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestMigrateCopiesKeysBetweenFilesystemStorages(t *testing.T) {
+	src := NewFilesystemStorage(t.TempDir(), "")
+	dst := NewFilesystemStorage(t.TempDir(), "")
+
+	keys := []string{"a.png", "b.png"}
+	for _, key := range keys {
+		if err := src.Save(key, []byte("content-"+key)); err != nil {
+			t.Fatalf("Save(%q): %v", key, err)
+		}
+	}
+
+	if err := Migrate(src, dst, keys); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	for _, key := range keys {
+		r, err := dst.Open(key)
+		if err != nil {
+			t.Fatalf("Open(%q) on destination: %v", key, err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("reading migrated %q: %v", key, err)
+		}
+		if string(data) != "content-"+key {
+			t.Fatalf("migrated %q = %q, want %q", key, data, "content-"+key)
+		}
+	}
+}
+
+func TestMigrateFailsWhenSourceKeyMissing(t *testing.T) {
+	src := NewFilesystemStorage(t.TempDir(), "")
+	dst := NewFilesystemStorage(t.TempDir(), "")
+
+	if err := Migrate(src, dst, []string{"missing.png"}); err == nil {
+		t.Fatal("expected an error when a source key does not exist")
+	}
+}